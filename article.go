@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Article is the source-agnostic shape every Source normalizes its
+// provider's response into.
+type Article struct {
+	Source      string    `json:"source"`
+	Author      string    `json:"author,omitempty"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	URL         string    `json:"url"`
+	URLToImage  string    `json:"urlToImage,omitempty"`
+	PublishedAt time.Time `json:"publishedAt"`
+	Content     string    `json:"content,omitempty"`
+
+	// RelatedURLs holds sibling articles clustered into this one by
+	// rankAndCluster, so notifiers can render a "+N related" footer.
+	RelatedURLs []string `json:"relatedUrls,omitempty"`
+}
+
+// relatedFooter renders the "+N related" footer listing sibling URLs for a
+// clustered article, or "" when it has no siblings.
+func (a Article) relatedFooter() string {
+	if len(a.RelatedURLs) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("+%d related: %s", len(a.RelatedURLs), strings.Join(a.RelatedURLs, ", "))
+}
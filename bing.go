@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// bingNewsSource fetches articles from Bing News Search. Bing's API doesn't
+// take an exact date range, so results are filtered to [from, to] locally.
+type bingNewsSource struct {
+	apiKey string
+}
+
+func newBingNewsSource(apiKey string) *bingNewsSource {
+	return &bingNewsSource{apiKey: apiKey}
+}
+
+func (s *bingNewsSource) Name() string { return "bing" }
+
+func (s *bingNewsSource) Fetch(ctx context.Context, query, from, to string) ([]Article, error) {
+	fromDate, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return nil, nonRetryableError{fmt.Errorf("parse from date: %w", err)}
+	}
+	toDate, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return nil, nonRetryableError{fmt.Errorf("parse to date: %w", err)}
+	}
+	toDate = toDate.Add(24*time.Hour - time.Second)
+
+	var bResp bingNewsResponse
+	err = withRetry(ctx, defaultRetry, func() error {
+		reqCtx, cancel := withRequestTimeout(ctx)
+		defer cancel()
+
+		values := url.Values{}
+		values.Add("q", query)
+
+		req, err := http.NewRequestWithContext(reqCtx, "GET", "https://api.bing.microsoft.com/v7.0/news/search?"+values.Encode(), nil)
+		if err != nil {
+			return nonRetryableError{err}
+		}
+		req.Header.Set("Ocp-Apim-Subscription-Key", s.apiKey)
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return fmt.Errorf("retryable status %d from Bing News", resp.StatusCode)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nonRetryableError{fmt.Errorf("Bing News returned status %d", resp.StatusCode)}
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &bResp)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	articles := make([]Article, 0, len(bResp.Value))
+	for _, a := range bResp.Value {
+		publishedAt, err := time.Parse(time.RFC3339, a.DatePublished)
+		if err != nil || publishedAt.Before(fromDate) || publishedAt.After(toDate) {
+			continue
+		}
+
+		var imageURL string
+		if a.Image != nil {
+			imageURL = a.Image.Thumbnail.ContentURL
+		}
+		var source string
+		if len(a.Provider) > 0 {
+			source = a.Provider[0].Name
+		}
+
+		articles = append(articles, Article{
+			Source:      source,
+			Title:       a.Name,
+			Description: a.Description,
+			URL:         a.URL,
+			URLToImage:  imageURL,
+			PublishedAt: publishedAt,
+		})
+	}
+	return articles, nil
+}
+
+type bingNewsResponse struct {
+	Value []bingNewsArticle `json:"value"`
+}
+
+type bingNewsArticle struct {
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	URL           string `json:"url"`
+	DatePublished string `json:"datePublished"`
+	Image         *struct {
+		Thumbnail struct {
+			ContentURL string `json:"contentUrl"`
+		} `json:"thumbnail"`
+	} `json:"image,omitempty"`
+	Provider []struct {
+		Name string `json:"name"`
+	} `json:"provider"`
+}
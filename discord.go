@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// discordNotifier posts a digest to a Discord Incoming Webhook as one embed
+// per article.
+type discordNotifier struct {
+	webhookURL string
+}
+
+func newDiscordNotifier(webhookURL string) *discordNotifier {
+	return &discordNotifier{webhookURL: webhookURL}
+}
+
+func (n *discordNotifier) Name() string { return "discord" }
+
+type discordPayload struct {
+	Content string         `json:"content"`
+	Embeds  []discordEmbed `json:"embeds,omitempty"`
+}
+
+type discordEmbed struct {
+	Title       string            `json:"title"`
+	URL         string            `json:"url"`
+	Description string            `json:"description,omitempty"`
+	Timestamp   string            `json:"timestamp,omitempty"`
+	Thumbnail   *discordThumbnail `json:"thumbnail,omitempty"`
+	Footer      *discordFooter    `json:"footer,omitempty"`
+}
+
+type discordThumbnail struct {
+	URL string `json:"url"`
+}
+
+type discordFooter struct {
+	Text string `json:"text"`
+}
+
+func (n *discordNotifier) Send(ctx context.Context, digest Digest) error {
+	payload := discordPayload{
+		Content: fmt.Sprintf("**%s** (%s ~ %s)", digest.Keyword, digest.From, digest.To),
+	}
+
+	for _, article := range digest.Articles {
+		description := truncate(article.Description, slackDescriptionMaxLen)
+		if footer := article.relatedFooter(); footer != "" {
+			description += "\n" + footer
+		}
+
+		embed := discordEmbed{
+			Title:       article.Title,
+			URL:         article.URL,
+			Description: description,
+			Timestamp:   article.PublishedAt.Format(time.RFC3339),
+			Footer:      &discordFooter{Text: article.Source},
+		}
+		if article.URLToImage != "" {
+			embed.Thumbnail = &discordThumbnail{URL: article.URLToImage}
+		}
+		payload.Embeds = append(payload.Embeds, embed)
+	}
+
+	return postJSON(ctx, n.webhookURL, payload)
+}
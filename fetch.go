@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultMaxConcurrency = 4
+	newsAPIRequestTimeout = 20 * time.Second
+
+	// requestDeadlineMargin is reserved off a Lambda invocation's remaining
+	// time so a source request never runs right up against it, leaving room
+	// for the rest of processKeyword (dedup, notify, mark seen) to run.
+	requestDeadlineMargin = 5 * time.Second
+
+	// newsAPIRateLimit caps outgoing requests to respect NewsAPI's quota.
+	newsAPIRateLimit = rate.Limit(1) // 1 request/sec
+	newsAPIRateBurst = 2
+)
+
+// withRequestTimeout derives a per-request context bounded by
+// newsAPIRequestTimeout, shortened further when ctx carries an earlier
+// deadline (e.g. the Lambda invocation's own deadline) so the request
+// leaves requestDeadlineMargin of headroom instead of running it out.
+func withRequestTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := newsAPIRequestTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline) - requestDeadlineMargin; remaining < timeout {
+			timeout = remaining
+		}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// KeywordResult is the outcome of fetching, deduping and notifying for a
+// single keyword.
+type KeywordResult struct {
+	Keyword      string `json:"keyword"`
+	Status       string `json:"status"` // "ok", "skipped", "error"
+	ArticleCount int    `json:"articleCount"`
+	Error        string `json:"error,omitempty"`
+}
+
+// RunSummary is HandleRequest's structured return value, intended to be
+// parsed back out of the CloudWatch log.
+type RunSummary struct {
+	Results []KeywordResult `json:"results"`
+}
+
+// processKeywords fetches, dedups and notifies for every key concurrently,
+// bounded by maxConcurrency and rate limited against NewsAPI's quota. A
+// failure fetching or notifying one keyword is recorded in its
+// KeywordResult and does not stop the others.
+func processKeywords(ctx context.Context, env Env, p *RequestParameter, keys []PickupKey, notifiers []Notifier, seenStore SeenStore, maxConcurrency int) []KeywordResult {
+	if maxConcurrency <= 0 {
+		maxConcurrency = defaultMaxConcurrency
+	}
+
+	limiter := rate.NewLimiter(newsAPIRateLimit, newsAPIRateBurst)
+	sem := make(chan struct{}, maxConcurrency)
+
+	results := make([]KeywordResult, len(keys))
+	var wg sync.WaitGroup
+
+	for i, key := range keys {
+		i, key := i, key
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = processKeyword(ctx, env, p, key, notifiers, seenStore, limiter)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func processKeyword(ctx context.Context, env Env, p *RequestParameter, key PickupKey, notifiers []Notifier, seenStore SeenStore, limiter *rate.Limiter) KeywordResult {
+	result := KeywordResult{Keyword: key.Keyword}
+
+	if err := limiter.Wait(ctx); err != nil {
+		result.Status = "error"
+		result.Error = err.Error()
+		return result
+	}
+
+	sources, err := buildSources(env, key)
+	if err != nil {
+		result.Status = "error"
+		result.Error = fmt.Sprintf("build sources: %v", err)
+		return result
+	}
+
+	articles, err := fetchAllSources(ctx, sources, key.Keyword, p.From, p.To)
+	if err != nil {
+		result.Status = "error"
+		result.Error = fmt.Sprintf("fetch: %v", err)
+		return result
+	}
+
+	if len(articles) <= key.NoticeLowerLimit {
+		result.Status = "skipped"
+		return result
+	}
+
+	freshArticles, err := seenStore.Filter(ctx, key.Keyword, articles)
+	if err != nil {
+		result.Status = "error"
+		result.Error = fmt.Sprintf("filter seen articles: %v", err)
+		return result
+	}
+	if len(freshArticles) == 0 {
+		result.Status = "skipped"
+		return result
+	}
+	result.ArticleCount = len(freshArticles)
+
+	digest := Digest{Keyword: key.Keyword, From: p.From, To: p.To, Articles: clusterArticles(key, freshArticles)}
+	sendErrs := sendToAll(ctx, notifiers, digest)
+	if len(sendErrs) > 0 {
+		result.Status = "error"
+		result.Error = multiError(sendErrs).Error()
+		if len(sendErrs) == len(notifiers) {
+			// Every notifier failed: nothing was delivered, so don't mark
+			// these articles seen or a working sink would never get them.
+			return result
+		}
+	}
+
+	// At least one notifier got the digest, so these articles must be
+	// marked seen even if another sink failed — otherwise a single broken
+	// sink (e.g. a bad webhook URL) would make every working sink
+	// re-receive the same digest on every subsequent run.
+	if err := seenStore.MarkSeen(ctx, key.Keyword, freshArticles); err != nil {
+		result.Status = "error"
+		result.Error = fmt.Sprintf("mark seen: %v", err)
+		return result
+	}
+
+	if result.Status == "" {
+		result.Status = "ok"
+	}
+	return result
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithRequestTimeoutNoDeadline(t *testing.T) {
+	reqCtx, cancel := withRequestTimeout(context.Background())
+	defer cancel()
+
+	deadline, ok := reqCtx.Deadline()
+	if !ok {
+		t.Fatal("withRequestTimeout() produced a context with no deadline")
+	}
+	if remaining := time.Until(deadline); remaining <= 0 || remaining > newsAPIRequestTimeout {
+		t.Errorf("remaining = %v, want (0, %v]", remaining, newsAPIRequestTimeout)
+	}
+}
+
+func TestWithRequestTimeoutCapsAtDefaultWhenDeadlineIsFar(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	reqCtx, cancel := withRequestTimeout(parent)
+	defer cancel()
+
+	deadline, _ := reqCtx.Deadline()
+	if remaining := time.Until(deadline); remaining > newsAPIRequestTimeout || remaining < newsAPIRequestTimeout-time.Second {
+		t.Errorf("remaining = %v, want ~%v (default timeout, not the hour-long parent deadline)", remaining, newsAPIRequestTimeout)
+	}
+}
+
+func TestWithRequestTimeoutShortensForNearDeadline(t *testing.T) {
+	parentTimeout := 10 * time.Second
+	parent, cancel := context.WithTimeout(context.Background(), parentTimeout)
+	defer cancel()
+
+	reqCtx, cancel := withRequestTimeout(parent)
+	defer cancel()
+
+	want := parentTimeout - requestDeadlineMargin
+	deadline, _ := reqCtx.Deadline()
+	if remaining := time.Until(deadline); remaining > want || remaining < want-time.Second {
+		t.Errorf("remaining = %v, want ~%v (parent deadline minus requestDeadlineMargin)", remaining, want)
+	}
+}
+
+func TestWithRequestTimeoutCanGoNegativeWhenDeadlineAlreadyWithinMargin(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	reqCtx, cancel := withRequestTimeout(parent)
+	defer cancel()
+
+	if err := reqCtx.Err(); err == nil {
+		t.Error("reqCtx should already be expired when the parent deadline is inside requestDeadlineMargin")
+	}
+}
+
+type fakeSource struct {
+	name     string
+	articles []Article
+	err      error
+}
+
+func (s *fakeSource) Name() string { return s.name }
+
+func (s *fakeSource) Fetch(ctx context.Context, query, from, to string) ([]Article, error) {
+	return s.articles, s.err
+}
+
+func TestFetchAllSourcesMergesAndDedupes(t *testing.T) {
+	sources := []Source{
+		&fakeSource{name: "a", articles: []Article{
+			{URL: "https://example.com/1"},
+			{URL: "https://example.com/2?utm_source=twitter"},
+		}},
+		&fakeSource{name: "b", articles: []Article{
+			{URL: "https://example.com/2"}, // same story as source a's, different utm tag
+			{URL: "https://example.com/3"},
+		}},
+	}
+
+	articles, err := fetchAllSources(context.Background(), sources, "golang", "2024-01-01", "2024-01-02")
+	if err != nil {
+		t.Fatalf("fetchAllSources() error = %v", err)
+	}
+	if len(articles) != 3 {
+		t.Fatalf("got %d articles, want 3 (deduped across sources): %+v", len(articles), articles)
+	}
+}
+
+func TestFetchAllSourcesToleratesPartialFailure(t *testing.T) {
+	sources := []Source{
+		&fakeSource{name: "ok", articles: []Article{{URL: "https://example.com/1"}}},
+		&fakeSource{name: "broken", err: errors.New("boom")},
+	}
+
+	articles, err := fetchAllSources(context.Background(), sources, "golang", "2024-01-01", "2024-01-02")
+	if err != nil {
+		t.Fatalf("fetchAllSources() error = %v, want nil (one source still succeeded)", err)
+	}
+	if len(articles) != 1 {
+		t.Fatalf("got %d articles, want 1", len(articles))
+	}
+}
+
+func TestFetchAllSourcesFailsWhenEverySourceFails(t *testing.T) {
+	sources := []Source{
+		&fakeSource{name: "a", err: errors.New("boom a")},
+		&fakeSource{name: "b", err: errors.New("boom b")},
+	}
+
+	articles, err := fetchAllSources(context.Background(), sources, "golang", "2024-01-01", "2024-01-02")
+	if err == nil {
+		t.Fatal("fetchAllSources() error = nil, want an aggregated error")
+	}
+	if articles != nil {
+		t.Errorf("articles = %+v, want nil", articles)
+	}
+}
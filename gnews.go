@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// gNewsSource fetches articles from GNews.io's search endpoint, giving
+// users without a NewsAPI key a way to still get digests.
+type gNewsSource struct {
+	apiKey string
+}
+
+func newGNewsSource(apiKey string) *gNewsSource {
+	return &gNewsSource{apiKey: apiKey}
+}
+
+func (s *gNewsSource) Name() string { return "gnews" }
+
+func (s *gNewsSource) Fetch(ctx context.Context, query, from, to string) ([]Article, error) {
+	var gResp gNewsResponse
+	err := withRetry(ctx, defaultRetry, func() error {
+		reqCtx, cancel := withRequestTimeout(ctx)
+		defer cancel()
+
+		values := url.Values{}
+		values.Add("q", query)
+		values.Add("from", from+"T00:00:00Z")
+		values.Add("to", to+"T23:59:59Z")
+		values.Add("token", s.apiKey)
+
+		req, err := http.NewRequestWithContext(reqCtx, "GET", "https://gnews.io/api/v4/search?"+values.Encode(), nil)
+		if err != nil {
+			return nonRetryableError{err}
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return fmt.Errorf("retryable status %d from GNews", resp.StatusCode)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nonRetryableError{fmt.Errorf("GNews returned status %d", resp.StatusCode)}
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &gResp)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	articles := make([]Article, 0, len(gResp.Articles))
+	for _, a := range gResp.Articles {
+		publishedAt, _ := time.Parse(time.RFC3339, a.PublishedAt)
+		articles = append(articles, Article{
+			Source:      a.Source.Name,
+			Title:       a.Title,
+			Description: a.Description,
+			URL:         a.URL,
+			URLToImage:  a.Image,
+			PublishedAt: publishedAt,
+			Content:     a.Content,
+		})
+	}
+	return articles, nil
+}
+
+type gNewsResponse struct {
+	TotalArticles int            `json:"totalArticles"`
+	Articles      []gNewsArticle `json:"articles"`
+}
+
+type gNewsArticle struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Content     string `json:"content"`
+	URL         string `json:"url"`
+	Image       string `json:"image"`
+	PublishedAt string `json:"publishedAt"`
+	Source      struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"source"`
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// hackerNewsSource fetches story submissions from the Hacker News Algolia
+// search API.
+type hackerNewsSource struct{}
+
+func newHackerNewsSource() *hackerNewsSource {
+	return &hackerNewsSource{}
+}
+
+func (s *hackerNewsSource) Name() string { return "hackernews" }
+
+func (s *hackerNewsSource) Fetch(ctx context.Context, query, from, to string) ([]Article, error) {
+	fromDate, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return nil, nonRetryableError{fmt.Errorf("parse from date: %w", err)}
+	}
+	toDate, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return nil, nonRetryableError{fmt.Errorf("parse to date: %w", err)}
+	}
+	toDate = toDate.Add(24*time.Hour - time.Second)
+
+	var hResp hackerNewsResponse
+	err = withRetry(ctx, defaultRetry, func() error {
+		reqCtx, cancel := withRequestTimeout(ctx)
+		defer cancel()
+
+		values := url.Values{}
+		values.Add("query", query)
+		values.Add("tags", "story")
+		values.Add("numericFilters", fmt.Sprintf("created_at_i>=%d,created_at_i<=%d", fromDate.Unix(), toDate.Unix()))
+
+		req, err := http.NewRequestWithContext(reqCtx, "GET", "https://hn.algolia.com/api/v1/search?"+values.Encode(), nil)
+		if err != nil {
+			return nonRetryableError{err}
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return fmt.Errorf("retryable status %d from HN Algolia", resp.StatusCode)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nonRetryableError{fmt.Errorf("HN Algolia returned status %d", resp.StatusCode)}
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &hResp)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	articles := make([]Article, 0, len(hResp.Hits))
+	for _, hit := range hResp.Hits {
+		if hit.URL == "" {
+			continue
+		}
+		articles = append(articles, Article{
+			Source:      "Hacker News",
+			Author:      hit.Author,
+			Title:       hit.Title,
+			URL:         hit.URL,
+			PublishedAt: time.Unix(hit.CreatedAtI, 0).UTC(),
+		})
+	}
+	return articles, nil
+}
+
+type hackerNewsResponse struct {
+	Hits []hackerNewsHit `json:"hits"`
+}
+
+type hackerNewsHit struct {
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	Author     string `json:"author"`
+	CreatedAtI int64  `json:"created_at_i"`
+}
@@ -5,11 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"net/http"
-	"net/url"
 	"os"
-	"strconv"
 	"time"
 
 	"github.com/aws/aws-lambda-go/lambda"
@@ -22,6 +18,25 @@ import (
 type Env struct {
 	Apikey     string // NewsAPI api key
 	WebhookURL string // Slack webhook url
+
+	// Sinks selects which notifiers to fan the digest out to, e.g.
+	// PICKUPNEWS_SINKS=slack,ses. Defaults to slack alone when empty.
+	Sinks []string
+
+	DiscordWebhookURL    string
+	TeamsWebhookURL      string
+	MattermostWebhookURL string
+	GenericWebhookURL    string
+
+	SESRegion      string
+	SESFromAddress string
+	SESToAddresses []string
+
+	SeenStoreTableName string
+
+	GNewsAPIKey string
+	BingAPIKey  string
+	RSSFeedURLs []string
 }
 
 type RequestParameter struct {
@@ -31,15 +46,50 @@ type RequestParameter struct {
 	S3ObjectKey      string
 	Keyword          string //This setting is for local environment.
 	NoticeLowerLimit int    //This setting is for local environment.
+
+	// DedupWindowDays sets how long a notified article's hash is kept in the
+	// SeenStore (DynamoDB TTL), so it isn't re-notified by a later overlapping run.
+	DedupWindowDays int
+
+	// MaxConcurrency bounds how many keywords are fetched/notified at once.
+	MaxConcurrency int
+
+	DryRun bool //This setting is for local environment.
 }
 
+// defaultDedupWindowDays is used when DedupWindowDays is unset.
+const defaultDedupWindowDays = 7
+
 type PickupKey struct {
 	Keyword string `json:"keyword"`
 
 	// Don't notify if the number of news is below NoticeLowerLimit
 	NoticeLowerLimit int `json:"noticeLowerLimit"`
+
+	// Sources selects which article sources to query for this keyword
+	// (e.g. "newsapi", "gnews", "bing", "rss", "hackernews"). Defaults to
+	// NewsAPI alone when empty.
+	Sources []string `json:"sources"`
+
+	// SimilarityThreshold is the cosine similarity a fresh article must
+	// reach against a cluster's centroid to be folded into it as a
+	// related article instead of becoming its own digest entry. Defaults
+	// to defaultSimilarityThreshold when zero.
+	SimilarityThreshold float64 `json:"similarityThreshold"`
+
+	// TopN caps how many clusters are included in the digest, keeping
+	// only the ones most relevant to Keyword. Defaults to defaultTopN
+	// when zero; a negative value disables the cap.
+	TopN int `json:"topN"`
 }
 
+// defaultSimilarityThreshold and defaultTopN are applied when a PickupKey
+// leaves the corresponding ranking field unset.
+const (
+	defaultSimilarityThreshold = 0.75
+	defaultTopN                = 20
+)
+
 func main() {
 	lambda.Start(HandleRequest)
 }
@@ -55,73 +105,29 @@ func HandleRequest(ctx context.Context, rp RequestParameter) (string, error) {
 	p := initRequestParameter(&rp)
 	keys := loadPickupKeys(&rp)
 
-	// create request
-	resuest, err := http.NewRequest("GET", "http://newsapi.org/v2/everything", nil)
+	notifiers, err := buildNotifiers(env)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return "", err
 	}
 
-	for _, key := range *keys {
-		values := url.Values{}
-		values.Add("qInTitle", key.Keyword)
-		values.Add("from", p.From)
-		values.Add("to", p.To)
-		values.Add("apiKey", env.Apikey)
-		resuest.URL.RawQuery = values.Encode()
-
-		// execute NewsAPI
-		client := new(http.Client)
-		resp, err := client.Do(resuest)
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		} else if resp.StatusCode != 200 {
-			fmt.Printf("Unable to get this url : http status is %d \n", resp.StatusCode)
-		}
-		defer resp.Body.Close()
-
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-
-		naResp := new(NewsAPIRespons)
-		if err := json.Unmarshal(body, &naResp); err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-
-		if naResp.TotalResults <= key.NoticeLowerLimit {
-			return fmt.Sprintf("TotalResult is lower NoticeLowerLimit. TotalResult:%d, NoticeLowerLimit:%d\n", naResp.TotalResults, key.NoticeLowerLimit), nil
-		}
+	var seenStore SeenStore
+	if rp.DryRun {
+		seenStore = newInMemorySeenStore()
+	} else {
+		seenStore = newDynamoDBSeenStore(env.SeenStoreTableName, p.DedupWindowDays)
+	}
 
-		messageHeader := "<!channel> Keyword: " + key.Keyword + " resultCount: " + strconv.Itoa(naResp.TotalResults) + " from: " + p.From + " to: " + p.To + "\n"
-		var messageDetail bytes.Buffer
-		for i, article := range naResp.Articles {
-			messageDetail.WriteString("No.")
-			messageDetail.WriteString(strconv.Itoa(i + 1))
-			messageDetail.WriteString(", ")
-			messageDetail.WriteString(article.Title)
-			messageDetail.WriteString(", ")
-			messageDetail.WriteString(article.URL)
-			messageDetail.WriteString("\n")
-		}
+	results := processKeywords(ctx, env, p, *keys, notifiers, seenStore, rp.MaxConcurrency)
 
-		notificationSlack(env, messageHeader+messageDetail.String())
+	summary, err := json.Marshal(RunSummary{Results: results})
+	if err != nil {
+		return "", fmt.Errorf("encode summary: %w", err)
 	}
-
-	return "Success notification.", nil
+	return string(summary), nil
 }
 
 func initRequestParameter(rp *RequestParameter) *RequestParameter {
-	t := time.Now().UTC()
-	loc, err := time.LoadLocation("Asia/Tokyo")
-	if err != nil {
-		loc = time.FixedZone("Asia/Tokyo", 9*60*60)
-	}
-	t = t.In(loc)
+	t := time.Now().UTC().In(tokyoLocation())
 
 	if rp.From == "" {
 		rp.From = t.AddDate(0, 0, -1).Format("2006-01-02") // Previous day
@@ -130,28 +136,11 @@ func initRequestParameter(rp *RequestParameter) *RequestParameter {
 	if rp.To == "" {
 		rp.To = t.Format("2006-01-02") // The day
 	}
-	return rp
-}
-
-func notificationSlack(env Env, message string) {
-	params := `{"text":"` + message + `"}`
-	resuest, err := http.NewRequest("POST", env.WebhookURL, bytes.NewBuffer([]byte(params)))
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-	resuest.Header.Set("Content-Type", "application/json")
 
-	// Execute slack webhook
-	client := new(http.Client)
-	resp, err := client.Do(resuest)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	} else if resp.StatusCode != 200 {
-		fmt.Printf("Unable to post this url : http status is %d \n", resp.StatusCode)
+	if rp.DedupWindowDays <= 0 {
+		rp.DedupWindowDays = defaultDedupWindowDays
 	}
-	defer resp.Body.Close()
+	return rp
 }
 
 func loadPickupKeys(rp *RequestParameter) *[]PickupKey {
@@ -193,21 +182,3 @@ func readS3File(rp *RequestParameter) []byte {
 	brb.ReadFrom(obj.Body)
 	return brb.Bytes()
 }
-
-type NewsAPIRespons struct {
-	Status       string `json:"status"`
-	TotalResults int    `json:"totalResults"`
-	Articles     []struct {
-		Source struct {
-			ID   string `json:"id"`
-			Name string `json:"name"`
-		} `json:"source"`
-		Author      string    `json:"author"`
-		Title       string    `json:"title"`
-		Description string    `json:"description"`
-		URL         string    `json:"url"`
-		URLToImage  string    `json:"urlToImage"`
-		PublishedAt time.Time `json:"publishedAt"`
-		Content     string    `json:"content"`
-	} `json:"articles"`
-}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// mattermostNotifier posts a digest to a Mattermost Incoming Webhook, which
+// speaks a Slack-compatible subset (text + simple attachments).
+type mattermostNotifier struct {
+	webhookURL string
+}
+
+func newMattermostNotifier(webhookURL string) *mattermostNotifier {
+	return &mattermostNotifier{webhookURL: webhookURL}
+}
+
+func (n *mattermostNotifier) Name() string { return "mattermost" }
+
+type mattermostPayload struct {
+	Text        string                 `json:"text"`
+	Attachments []mattermostAttachment `json:"attachments,omitempty"`
+}
+
+type mattermostAttachment struct {
+	Fallback   string `json:"fallback"`
+	AuthorName string `json:"author_name,omitempty"`
+	Title      string `json:"title"`
+	TitleLink  string `json:"title_link"`
+	Text       string `json:"text,omitempty"`
+	ImageURL   string `json:"image_url,omitempty"`
+}
+
+func (n *mattermostNotifier) Send(ctx context.Context, digest Digest) error {
+	payload := mattermostPayload{
+		Text: fmt.Sprintf("##### %s (%s ~ %s)", digest.Keyword, digest.From, digest.To),
+	}
+
+	for _, article := range digest.Articles {
+		text := truncate(article.Description, slackDescriptionMaxLen)
+		if footer := article.relatedFooter(); footer != "" {
+			text += "\n" + footer
+		}
+
+		payload.Attachments = append(payload.Attachments, mattermostAttachment{
+			Fallback:   article.Title,
+			AuthorName: article.Source,
+			Title:      article.Title,
+			TitleLink:  article.URL,
+			Text:       text,
+			ImageURL:   article.URLToImage,
+		})
+	}
+
+	return postJSON(ctx, n.webhookURL, payload)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// newsAPISource fetches articles from NewsAPI's "everything" endpoint,
+// searching qInTitle for query.
+type newsAPISource struct {
+	apiKey string
+}
+
+func newNewsAPISource(apiKey string) *newsAPISource {
+	return &newsAPISource{apiKey: apiKey}
+}
+
+func (s *newsAPISource) Name() string { return "newsapi" }
+
+func (s *newsAPISource) Fetch(ctx context.Context, query, from, to string) ([]Article, error) {
+	var naResp newsAPIResponse
+	err := withRetry(ctx, defaultRetry, func() error {
+		reqCtx, cancel := withRequestTimeout(ctx)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(reqCtx, "GET", "http://newsapi.org/v2/everything", nil)
+		if err != nil {
+			return nonRetryableError{err}
+		}
+
+		values := url.Values{}
+		values.Add("qInTitle", query)
+		values.Add("from", from)
+		values.Add("to", to)
+		values.Add("apiKey", s.apiKey)
+		req.URL.RawQuery = values.Encode()
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return fmt.Errorf("retryable status %d from NewsAPI", resp.StatusCode)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nonRetryableError{fmt.Errorf("NewsAPI returned status %d", resp.StatusCode)}
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(body, &naResp); err != nil {
+			return nonRetryableError{err}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	articles := make([]Article, 0, len(naResp.Articles))
+	for _, a := range naResp.Articles {
+		articles = append(articles, Article{
+			Source:      a.Source.Name,
+			Author:      a.Author,
+			Title:       a.Title,
+			Description: a.Description,
+			URL:         a.URL,
+			URLToImage:  a.URLToImage,
+			PublishedAt: a.PublishedAt,
+			Content:     a.Content,
+		})
+	}
+	return articles, nil
+}
+
+type newsAPIResponse struct {
+	Status       string              `json:"status"`
+	TotalResults int                 `json:"totalResults"`
+	Articles     []newsAPIRawArticle `json:"articles"`
+}
+
+type newsAPIRawArticle struct {
+	Source struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"source"`
+	Author      string    `json:"author"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	URL         string    `json:"url"`
+	URLToImage  string    `json:"urlToImage"`
+	PublishedAt time.Time `json:"publishedAt"`
+	Content     string    `json:"content"`
+}
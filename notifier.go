@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Digest is the keyword-scoped result of a single fetch, ready to be
+// rendered by any Notifier implementation.
+type Digest struct {
+	Keyword  string
+	From     string
+	To       string
+	Articles []Article
+}
+
+// Notifier delivers a Digest to a single destination (chat app, webhook,
+// email, ...). Send may be called more than once for the same digest if a
+// previous attempt failed, so implementations shouldn't assume exactly-once
+// delivery.
+type Notifier interface {
+	Name() string
+	Send(ctx context.Context, digest Digest) error
+}
+
+// buildNotifiers constructs one Notifier per sink named in env.Sinks. When
+// no sinks are configured it falls back to Slack alone, matching the
+// behaviour before sinks became pluggable.
+func buildNotifiers(env Env) ([]Notifier, error) {
+	if len(env.Sinks) == 0 {
+		return []Notifier{newSlackNotifier(env.WebhookURL)}, nil
+	}
+
+	var notifiers []Notifier
+	for _, sink := range env.Sinks {
+		switch strings.ToLower(strings.TrimSpace(sink)) {
+		case "slack":
+			notifiers = append(notifiers, newSlackNotifier(env.WebhookURL))
+		case "discord":
+			notifiers = append(notifiers, newDiscordNotifier(env.DiscordWebhookURL))
+		case "teams":
+			notifiers = append(notifiers, newTeamsNotifier(env.TeamsWebhookURL))
+		case "mattermost":
+			notifiers = append(notifiers, newMattermostNotifier(env.MattermostWebhookURL))
+		case "webhook":
+			notifiers = append(notifiers, newWebhookNotifier(env.GenericWebhookURL))
+		case "ses":
+			notifiers = append(notifiers, newSESNotifier(env.SESRegion, env.SESFromAddress, env.SESToAddresses))
+		default:
+			return nil, fmt.Errorf("unknown notification sink %q", sink)
+		}
+	}
+	return notifiers, nil
+}
+
+// sendToAll dispatches digest to every notifier, retrying each one
+// independently with backoff, and collects every failure instead of
+// aborting on the first one so a broken sink doesn't suppress the rest.
+func sendToAll(ctx context.Context, notifiers []Notifier, digest Digest) []error {
+	var errs []error
+	for _, n := range notifiers {
+		n := n
+		err := withRetry(ctx, defaultRetry, func() error {
+			return n.Send(ctx, digest)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+		}
+	}
+	return errs
+}
+
+// multiError joins several independent failures into one error without
+// losing any of them, for cases like sendToAll where we want to keep going
+// after the first failure.
+type multiError []error
+
+func (m multiError) Error() string {
+	parts := make([]string, len(m))
+	for i, e := range m {
+		parts[i] = e.Error()
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// stopwords is a small English/Japanese list filtered out before TF-IDF
+// weighting, since they carry little topical signal.
+var stopwords = map[string]bool{
+	"the": true, "a": true, "an": true, "and": true, "or": true, "of": true,
+	"to": true, "in": true, "on": true, "for": true, "is": true, "are": true,
+	"was": true, "were": true, "with": true, "at": true, "by": true, "this": true,
+	"that": true, "it": true, "as": true, "be": true, "from": true,
+	"の": true, "は": true, "を": true, "に": true, "が": true, "と": true,
+	"で": true, "た": true, "し": true, "です": true, "ます": true, "する": true,
+}
+
+// tokenize lowercases s and splits it into runs of unicode letters,
+// dropping stopwords.
+func tokenize(s string) []string {
+	var tokens []string
+	var current strings.Builder
+
+	flush := func() {
+		if current.Len() == 0 {
+			return
+		}
+		token := strings.ToLower(current.String())
+		if !stopwords[token] {
+			tokens = append(tokens, token)
+		}
+		current.Reset()
+	}
+
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			current.WriteRune(r)
+		} else {
+			flush()
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+// tfidfVector is a sparse bag-of-words vector keyed by term. Vectors
+// produced by tfidfModel.vectorize are L2-normalized, so their dot product
+// is already cosine similarity.
+type tfidfVector map[string]float64
+
+func (v tfidfVector) cosineSimilarity(other tfidfVector) float64 {
+	small, large := v, other
+	if len(other) < len(v) {
+		small, large = other, v
+	}
+	var dot float64
+	for term, weight := range small {
+		dot += weight * large[term]
+	}
+	return dot
+}
+
+func (v tfidfVector) normalize() {
+	var sumSquares float64
+	for _, weight := range v {
+		sumSquares += weight * weight
+	}
+	if sumSquares == 0 {
+		return
+	}
+	norm := math.Sqrt(sumSquares)
+	for term := range v {
+		v[term] /= norm
+	}
+}
+
+// tfidfModel holds document frequencies for a fixed corpus, so the same
+// idf weights can be reused to vectorize both corpus documents and an
+// arbitrary query.
+type tfidfModel struct {
+	df map[string]int
+	n  float64
+}
+
+func newTFIDFModel(docs [][]string) *tfidfModel {
+	df := make(map[string]int)
+	for _, doc := range docs {
+		seen := make(map[string]bool)
+		for _, term := range doc {
+			if !seen[term] {
+				df[term]++
+				seen[term] = true
+			}
+		}
+	}
+	return &tfidfModel{df: df, n: float64(len(docs))}
+}
+
+// vectorize applies 1+log(tf) term-frequency weighting and log(N/df)
+// inverse document frequency weighting, then L2-normalizes the result.
+// Terms absent from the corpus carry no weight.
+func (m *tfidfModel) vectorize(doc []string) tfidfVector {
+	counts := make(map[string]int)
+	for _, term := range doc {
+		counts[term]++
+	}
+
+	vec := make(tfidfVector, len(counts))
+	for term, tf := range counts {
+		df := m.df[term]
+		if df == 0 {
+			continue
+		}
+		idf := math.Log(m.n / float64(df))
+		vec[term] = (1 + math.Log(float64(tf))) * idf
+	}
+	vec.normalize()
+	return vec
+}
+
+// Cluster groups near-duplicate articles behind a single digest entry.
+type Cluster struct {
+	Primary Article
+	Related []Article
+}
+
+type clusterState struct {
+	primary Article
+	related []Article
+	sum     tfidfVector
+	count   int
+}
+
+func newClusterState(article Article, vector tfidfVector) *clusterState {
+	sum := make(tfidfVector, len(vector))
+	for term, weight := range vector {
+		sum[term] = weight
+	}
+	return &clusterState{primary: article, sum: sum, count: 1}
+}
+
+func (c *clusterState) centroid() tfidfVector {
+	centroid := make(tfidfVector, len(c.sum))
+	for term, weight := range c.sum {
+		centroid[term] = weight / float64(c.count)
+	}
+	centroid.normalize()
+	return centroid
+}
+
+func (c *clusterState) add(article Article, vector tfidfVector) {
+	c.related = append(c.related, article)
+	c.count++
+	for term, weight := range vector {
+		c.sum[term] += weight
+	}
+}
+
+// rankAndCluster scores each article by TF-IDF cosine similarity to a
+// keyword-derived pseudo-document and sorts descending, then greedily
+// clusters near-duplicates by single-linkage: an article joins the first
+// existing cluster whose centroid it's at least threshold-similar to, else
+// it starts a new cluster. At most topN clusters are returned (topN<=0
+// means unlimited).
+func rankAndCluster(keyword string, articles []Article, threshold float64, topN int) []Cluster {
+	if len(articles) == 0 {
+		return nil
+	}
+
+	docs := make([][]string, len(articles))
+	for i, article := range articles {
+		docs[i] = tokenize(article.Title + " " + article.Description)
+	}
+	model := newTFIDFModel(docs)
+
+	vectors := make([]tfidfVector, len(docs))
+	for i, doc := range docs {
+		vectors[i] = model.vectorize(doc)
+	}
+	queryVector := model.vectorize(tokenize(keyword))
+
+	type scoredArticle struct {
+		article Article
+		vector  tfidfVector
+		score   float64
+	}
+	scored := make([]scoredArticle, len(articles))
+	for i, article := range articles {
+		scored[i] = scoredArticle{
+			article: article,
+			vector:  vectors[i],
+			score:   vectors[i].cosineSimilarity(queryVector),
+		}
+	}
+	sort.SliceStable(scored, func(i, j int) bool { return scored[i].score > scored[j].score })
+
+	var clusters []*clusterState
+	for _, sa := range scored {
+		placed := false
+		for _, c := range clusters {
+			if c.centroid().cosineSimilarity(sa.vector) >= threshold {
+				c.add(sa.article, sa.vector)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			clusters = append(clusters, newClusterState(sa.article, sa.vector))
+		}
+	}
+
+	if topN > 0 && len(clusters) > topN {
+		clusters = clusters[:topN]
+	}
+
+	result := make([]Cluster, len(clusters))
+	for i, c := range clusters {
+		result[i] = Cluster{Primary: c.primary, Related: c.related}
+	}
+	return result
+}
+
+// clusterArticles ranks and clusters articles per key's thresholds and
+// flattens the result into one Article per cluster, with RelatedURLs set
+// to its siblings' URLs for the "+N related" footer.
+func clusterArticles(key PickupKey, articles []Article) []Article {
+	threshold := key.SimilarityThreshold
+	if threshold <= 0 {
+		threshold = defaultSimilarityThreshold
+	}
+
+	topN := key.TopN
+	switch {
+	case topN == 0:
+		topN = defaultTopN
+	case topN < 0:
+		topN = 0 // unlimited
+	}
+
+	clusters := rankAndCluster(key.Keyword, articles, threshold, topN)
+
+	digestArticles := make([]Article, len(clusters))
+	for i, c := range clusters {
+		article := c.Primary
+		for _, related := range c.Related {
+			article.RelatedURLs = append(article.RelatedURLs, related.URL)
+		}
+		digestArticles[i] = article
+	}
+	return digestArticles
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{
+			name: "lowercases and splits on non-letters",
+			in:   "Go 1.21 Released!",
+			want: []string{"go", "released"},
+		},
+		{
+			name: "drops stopwords",
+			in:   "The quick fox and the hound",
+			want: []string{"quick", "fox", "hound"},
+		},
+		{
+			name: "handles japanese text",
+			in:   "日本 の ニュース です",
+			want: []string{"日本", "ニュース"},
+		},
+		{
+			name: "empty string yields no tokens",
+			in:   "   ",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tokenize(tt.in)
+			if len(got) != len(tt.want) {
+				t.Fatalf("tokenize(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("tokenize(%q)[%d] = %q, want %q", tt.in, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTFIDFVectorizeWeightsRareTermsHigher(t *testing.T) {
+	docs := [][]string{
+		{"rocket", "launch"},
+		{"rocket", "weather"},
+		{"rocket", "weather"},
+	}
+	model := newTFIDFModel(docs)
+
+	vec := model.vectorize([]string{"rocket", "launch"})
+
+	// "rocket" appears in every doc (idf == 0) so it should carry no
+	// weight, while "launch" is rare and should dominate the vector.
+	if weight := vec["rocket"]; weight != 0 {
+		t.Errorf("vec[rocket] = %v, want 0 (term present in every doc)", weight)
+	}
+	if vec["launch"] <= 0 {
+		t.Errorf("vec[launch] = %v, want > 0", vec["launch"])
+	}
+}
+
+func TestTFIDFVectorizeL2Normalized(t *testing.T) {
+	docs := [][]string{
+		{"apple", "banana"},
+		{"banana", "cherry"},
+		{"cherry", "date"},
+	}
+	model := newTFIDFModel(docs)
+
+	vec := model.vectorize([]string{"apple", "banana", "banana"})
+
+	var sumSquares float64
+	for _, weight := range vec {
+		sumSquares += weight * weight
+	}
+	if norm := math.Sqrt(sumSquares); math.Abs(norm-1) > 1e-9 {
+		t.Errorf("||vec|| = %v, want 1", norm)
+	}
+}
+
+func TestTFIDFVectorizeIgnoresUnseenTerms(t *testing.T) {
+	model := newTFIDFModel([][]string{{"apple"}, {"banana"}})
+
+	vec := model.vectorize([]string{"unseen"})
+	if len(vec) != 0 {
+		t.Errorf("vectorize([unseen]) = %v, want empty vector", vec)
+	}
+}
+
+func TestRankAndClusterEmpty(t *testing.T) {
+	if got := rankAndCluster("golang", nil, 0.75, 20); got != nil {
+		t.Errorf("rankAndCluster(nil) = %v, want nil", got)
+	}
+}
+
+func TestRankAndClusterGroupsNearDuplicates(t *testing.T) {
+	articles := []Article{
+		{URL: "https://a.example/1", Title: "Go 1.21 released with new features", Description: "The Go team shipped Go 1.21"},
+		{URL: "https://a.example/2", Title: "Go 1.21 is out with new features", Description: "Go 1.21 has been released"},
+		{URL: "https://a.example/3", Title: "Local elections held this weekend", Description: "Voters across the region cast ballots"},
+	}
+
+	clusters := rankAndCluster("go release", articles, 0.2, 0)
+
+	if len(clusters) != 2 {
+		t.Fatalf("got %d clusters, want 2: %+v", len(clusters), clusters)
+	}
+
+	var found bool
+	for _, c := range clusters {
+		if len(c.Related) == 1 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the two Go articles to be clustered together, got %+v", clusters)
+	}
+}
+
+func TestRankAndClusterRespectsTopN(t *testing.T) {
+	articles := []Article{
+		{URL: "https://a.example/1", Title: "Alpha story about rockets"},
+		{URL: "https://a.example/2", Title: "Beta story about weather"},
+		{URL: "https://a.example/3", Title: "Gamma story about sports"},
+	}
+
+	clusters := rankAndCluster("news", articles, 0.99, 2)
+	if len(clusters) != 2 {
+		t.Fatalf("got %d clusters, want topN=2", len(clusters))
+	}
+}
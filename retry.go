@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+// retryConfig controls the exponential backoff schedule shared by
+// notification sinks and source fetches.
+type retryConfig struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+}
+
+var defaultRetry = retryConfig{MaxAttempts: 3, BaseDelay: 500 * time.Millisecond}
+
+// nonRetryableError marks an error that withRetry should surface
+// immediately instead of retrying, e.g. a non-transient 4xx response.
+type nonRetryableError struct{ err error }
+
+func (e nonRetryableError) Error() string { return e.err.Error() }
+func (e nonRetryableError) Unwrap() error { return e.err }
+
+// withRetry calls fn until it succeeds, it returns a nonRetryableError, or
+// the attempt budget is exhausted, sleeping an exponentially increasing
+// delay between attempts. It returns early if ctx is cancelled.
+func withRetry(ctx context.Context, cfg retryConfig, fn func() error) error {
+	var err error
+	for attempt := 0; attempt < cfg.MaxAttempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+
+		var nonRetryable nonRetryableError
+		if errors.As(err, &nonRetryable) {
+			return nonRetryable.err
+		}
+		if attempt == cfg.MaxAttempts-1 {
+			break
+		}
+
+		delay := cfg.BaseDelay * time.Duration(math.Pow(2, float64(attempt)))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithRetrySucceedsFirstTry(t *testing.T) {
+	attempts := 0
+	err := withRetry(context.Background(), retryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond}, func() error {
+		attempts++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withRetry() = %v, want nil", err)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestWithRetryRetriesUntilSuccess(t *testing.T) {
+	attempts := 0
+	err := withRetry(context.Background(), retryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond}, func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withRetry() = %v, want nil", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestWithRetryExhaustsAttempts(t *testing.T) {
+	attempts := 0
+	wantErr := errors.New("persistent")
+	err := withRetry(context.Background(), retryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond}, func() error {
+		attempts++
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("withRetry() = %v, want %v", err, wantErr)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want MaxAttempts=3", attempts)
+	}
+}
+
+func TestWithRetryShortCircuitsOnNonRetryableError(t *testing.T) {
+	attempts := 0
+	wantErr := errors.New("bad request")
+	err := withRetry(context.Background(), retryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond}, func() error {
+		attempts++
+		return nonRetryableError{wantErr}
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("withRetry() = %v, want %v", err, wantErr)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (should not retry a nonRetryableError)", attempts)
+	}
+}
+
+func TestWithRetryStopsOnContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	err := withRetry(ctx, retryConfig{MaxAttempts: 5, BaseDelay: time.Hour}, func() error {
+		attempts++
+		return errors.New("transient")
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("withRetry() = %v, want context.Canceled", err)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (should stop before sleeping out the cancelled ctx)", attempts)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// rssSource matches query against the title of items in a fixed set of
+// RSS/Atom feeds, since feeds have no server-side search of their own.
+type rssSource struct {
+	feedURLs []string
+}
+
+func newRSSSource(feedURLs []string) *rssSource {
+	return &rssSource{feedURLs: feedURLs}
+}
+
+func (s *rssSource) Name() string { return "rss" }
+
+func (s *rssSource) Fetch(ctx context.Context, query, from, to string) ([]Article, error) {
+	fromDate, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return nil, nonRetryableError{fmt.Errorf("parse from date: %w", err)}
+	}
+	toDate, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return nil, nonRetryableError{fmt.Errorf("parse to date: %w", err)}
+	}
+	toDate = toDate.Add(24*time.Hour - time.Second)
+
+	lowerQuery := strings.ToLower(query)
+	parser := gofeed.NewParser()
+
+	var articles []Article
+	var errs []error
+	for _, feedURL := range s.feedURLs {
+		feed, err := parser.ParseURLWithContext(feedURL, ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", feedURL, err))
+			continue
+		}
+
+		for _, item := range feed.Items {
+			if !strings.Contains(strings.ToLower(item.Title), lowerQuery) {
+				continue
+			}
+			if item.PublishedParsed == nil || item.PublishedParsed.Before(fromDate) || item.PublishedParsed.After(toDate) {
+				continue
+			}
+
+			articles = append(articles, Article{
+				Source:      feed.Title,
+				Author:      feedItemAuthor(item),
+				Title:       item.Title,
+				Description: item.Description,
+				URL:         item.Link,
+				URLToImage:  feedItemImage(item),
+				PublishedAt: *item.PublishedParsed,
+				Content:     item.Content,
+			})
+		}
+	}
+
+	if len(errs) > 0 && len(articles) == 0 {
+		return nil, multiError(errs)
+	}
+	return articles, nil
+}
+
+func feedItemAuthor(item *gofeed.Item) string {
+	if item.Author != nil {
+		return item.Author.Name
+	}
+	return ""
+}
+
+func feedItemImage(item *gofeed.Item) string {
+	if item.Image != nil {
+		return item.Image.URL
+	}
+	return ""
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// SeenStore records which articles have already been notified for a given
+// keyword, so overlapping from/to windows (e.g. an hourly cron) don't
+// re-notify the same story.
+type SeenStore interface {
+	// Filter returns the subset of articles not yet recorded as seen for keyword.
+	Filter(ctx context.Context, keyword string, articles []Article) ([]Article, error)
+	// MarkSeen records articles as seen for keyword.
+	MarkSeen(ctx context.Context, keyword string, articles []Article) error
+}
+
+// articleHash normalizes an article URL (strip utm_* params, lowercase
+// host) and returns a stable key suitable for a SeenStore.
+func articleHash(rawURL string) string {
+	sum := sha256.Sum256([]byte(normalizeArticleURL(rawURL)))
+	return hex.EncodeToString(sum[:])
+}
+
+func normalizeArticleURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	u.Host = strings.ToLower(u.Host)
+
+	q := u.Query()
+	for param := range q {
+		if strings.HasPrefix(strings.ToLower(param), "utm_") {
+			q.Del(param)
+		}
+	}
+	u.RawQuery = q.Encode()
+	u.Fragment = ""
+
+	return u.String()
+}
+
+const (
+	seenStoreKeywordAttr   = "Keyword"
+	seenStoreHashAttr      = "ArticleHash"
+	seenStoreFirstSeenAttr = "FirstSeenAt"
+	seenStoreTTLAttr       = "ExpiresAt"
+
+	// dynamoDBBatchWriteLimit is the max number of items DynamoDB accepts
+	// per BatchWriteItem call.
+	dynamoDBBatchWriteLimit = 25
+
+	// dynamoDBBatchGetLimit is the max number of keys DynamoDB accepts per
+	// BatchGetItem call.
+	dynamoDBBatchGetLimit = 100
+)
+
+// dynamoDBSeenStore is the production SeenStore. Each item's ExpiresAt is a
+// DynamoDB TTL attribute set DedupWindowDays out, so old entries age out on
+// their own.
+type dynamoDBSeenStore struct {
+	svc       *dynamodb.DynamoDB
+	tableName string
+	ttlDays   int
+}
+
+func newDynamoDBSeenStore(tableName string, ttlDays int) *dynamoDBSeenStore {
+	sess := session.Must(session.NewSession())
+	return &dynamoDBSeenStore{svc: dynamodb.New(sess), tableName: tableName, ttlDays: ttlDays}
+}
+
+func (s *dynamoDBSeenStore) Filter(ctx context.Context, keyword string, articles []Article) ([]Article, error) {
+	seen := make(map[string]bool, len(articles))
+	for i := 0; i < len(articles); i += dynamoDBBatchGetLimit {
+		end := i + dynamoDBBatchGetLimit
+		if end > len(articles) {
+			end = len(articles)
+		}
+		if err := s.batchGetSeen(ctx, keyword, articles[i:end], seen); err != nil {
+			return nil, err
+		}
+	}
+
+	var fresh []Article
+	for _, article := range articles {
+		if !seen[articleHash(article.URL)] {
+			fresh = append(fresh, article)
+		}
+	}
+	return fresh, nil
+}
+
+// batchGetSeen looks up articles' hashes for keyword in a single
+// BatchGetItem call (at most dynamoDBBatchGetLimit of them), retrying any
+// UnprocessedKeys DynamoDB throttled away, and records which hashes it
+// found in seen.
+func (s *dynamoDBSeenStore) batchGetSeen(ctx context.Context, keyword string, articles []Article, seen map[string]bool) error {
+	keys := make([]map[string]*dynamodb.AttributeValue, 0, len(articles))
+	for _, article := range articles {
+		keys = append(keys, map[string]*dynamodb.AttributeValue{
+			seenStoreKeywordAttr: {S: aws.String(keyword)},
+			seenStoreHashAttr:    {S: aws.String(articleHash(article.URL))},
+		})
+	}
+
+	request := map[string]*dynamodb.KeysAndAttributes{s.tableName: {Keys: keys}}
+	for {
+		out, err := s.svc.BatchGetItemWithContext(ctx, &dynamodb.BatchGetItemInput{RequestItems: request})
+		if err != nil {
+			return fmt.Errorf("batch get seen items: %w", err)
+		}
+		for _, item := range out.Responses[s.tableName] {
+			if hash := item[seenStoreHashAttr]; hash != nil && hash.S != nil {
+				seen[*hash.S] = true
+			}
+		}
+		if len(out.UnprocessedKeys) == 0 {
+			return nil
+		}
+		request = out.UnprocessedKeys
+	}
+}
+
+func (s *dynamoDBSeenStore) MarkSeen(ctx context.Context, keyword string, articles []Article) error {
+	if len(articles) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	expiresAt := strconv.FormatInt(now.AddDate(0, 0, s.ttlDays).Unix(), 10)
+
+	writeRequests := make([]*dynamodb.WriteRequest, 0, len(articles))
+	for _, article := range articles {
+		writeRequests = append(writeRequests, &dynamodb.WriteRequest{
+			PutRequest: &dynamodb.PutRequest{
+				Item: map[string]*dynamodb.AttributeValue{
+					seenStoreKeywordAttr:   {S: aws.String(keyword)},
+					seenStoreHashAttr:      {S: aws.String(articleHash(article.URL))},
+					seenStoreFirstSeenAttr: {S: aws.String(now.Format(time.RFC3339))},
+					seenStoreTTLAttr:       {N: aws.String(expiresAt)},
+				},
+			},
+		})
+	}
+
+	for i := 0; i < len(writeRequests); i += dynamoDBBatchWriteLimit {
+		end := i + dynamoDBBatchWriteLimit
+		if end > len(writeRequests) {
+			end = len(writeRequests)
+		}
+		if err := s.batchWriteSeen(ctx, writeRequests[i:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// batchWriteSeen writes a single batch of write requests (at most
+// dynamoDBBatchWriteLimit of them), retrying any UnprocessedItems DynamoDB
+// throttled away instead of dropping them.
+func (s *dynamoDBSeenStore) batchWriteSeen(ctx context.Context, writeRequests []*dynamodb.WriteRequest) error {
+	request := map[string][]*dynamodb.WriteRequest{s.tableName: writeRequests}
+	for {
+		out, err := s.svc.BatchWriteItemWithContext(ctx, &dynamodb.BatchWriteItemInput{RequestItems: request})
+		if err != nil {
+			return fmt.Errorf("batch write seen items: %w", err)
+		}
+		if len(out.UnprocessedItems) == 0 {
+			return nil
+		}
+		request = out.UnprocessedItems
+	}
+}
+
+// inMemorySeenStore is a SeenStore for --dry-run local testing, backed by a
+// process-local map instead of DynamoDB.
+type inMemorySeenStore struct {
+	seen map[string]map[string]time.Time
+}
+
+func newInMemorySeenStore() *inMemorySeenStore {
+	return &inMemorySeenStore{seen: make(map[string]map[string]time.Time)}
+}
+
+func (s *inMemorySeenStore) Filter(ctx context.Context, keyword string, articles []Article) ([]Article, error) {
+	var fresh []Article
+	for _, article := range articles {
+		if _, ok := s.seen[keyword][articleHash(article.URL)]; !ok {
+			fresh = append(fresh, article)
+		}
+	}
+	return fresh, nil
+}
+
+func (s *inMemorySeenStore) MarkSeen(ctx context.Context, keyword string, articles []Article) error {
+	if s.seen[keyword] == nil {
+		s.seen[keyword] = make(map[string]time.Time)
+	}
+	now := time.Now().UTC()
+	for _, article := range articles {
+		s.seen[keyword][articleHash(article.URL)] = now
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import "testing"
+
+func TestNormalizeArticleURL(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "strips utm params",
+			in:   "https://example.com/a?utm_source=twitter&utm_medium=social&id=1",
+			want: "https://example.com/a?id=1",
+		},
+		{
+			name: "lowercases host",
+			in:   "https://EXAMPLE.com/a",
+			want: "https://example.com/a",
+		},
+		{
+			name: "drops fragment",
+			in:   "https://example.com/a#section",
+			want: "https://example.com/a",
+		},
+		{
+			name: "leaves non-utm query untouched",
+			in:   "https://example.com/a?id=1&ref=home",
+			want: "https://example.com/a?id=1&ref=home",
+		},
+		{
+			name: "invalid url passes through unchanged",
+			in:   "://not a url",
+			want: "://not a url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeArticleURL(tt.in); got != tt.want {
+				t.Errorf("normalizeArticleURL(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArticleHash(t *testing.T) {
+	base := "https://example.com/a?id=1"
+	withUTM := "https://example.com/a?id=1&utm_source=twitter"
+	differentCase := "https://EXAMPLE.com/a?id=1"
+	different := "https://example.com/b?id=1"
+
+	if articleHash(base) != articleHash(withUTM) {
+		t.Errorf("articleHash should ignore utm params: %q vs %q", base, withUTM)
+	}
+	if articleHash(base) != articleHash(differentCase) {
+		t.Errorf("articleHash should ignore host case: %q vs %q", base, differentCase)
+	}
+	if articleHash(base) == articleHash(different) {
+		t.Errorf("articleHash should differ for distinct URLs: %q vs %q", base, different)
+	}
+	if got := articleHash(base); len(got) != 64 {
+		t.Errorf("articleHash(%q) = %q, want a 64-char hex sha256 digest", base, got)
+	}
+}
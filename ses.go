@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ses"
+)
+
+// sesNotifier emails a digest via AWS SES.
+type sesNotifier struct {
+	region string
+	from   string
+	to     []string
+}
+
+func newSESNotifier(region, from string, to []string) *sesNotifier {
+	return &sesNotifier{region: region, from: from, to: to}
+}
+
+func (n *sesNotifier) Name() string { return "ses" }
+
+func (n *sesNotifier) Send(ctx context.Context, digest Digest) error {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(n.region)})
+	if err != nil {
+		return fmt.Errorf("create aws session: %w", err)
+	}
+
+	subject := fmt.Sprintf("[pickup-news] %s (%s ~ %s)", digest.Keyword, digest.From, digest.To)
+
+	var body strings.Builder
+	for _, article := range digest.Articles {
+		fmt.Fprintf(&body, "%s\n%s\n%s\n", article.Title, article.URL, truncate(article.Description, slackDescriptionMaxLen))
+		if footer := article.relatedFooter(); footer != "" {
+			fmt.Fprintf(&body, "%s\n", footer)
+		}
+		body.WriteString("\n")
+	}
+
+	toAddresses := make([]*string, len(n.to))
+	for i, addr := range n.to {
+		toAddresses[i] = aws.String(addr)
+	}
+
+	_, err = ses.New(sess).SendEmailWithContext(ctx, &ses.SendEmailInput{
+		Source:      aws.String(n.from),
+		Destination: &ses.Destination{ToAddresses: toAddresses},
+		Message: &ses.Message{
+			Subject: &ses.Content{Data: aws.String(subject)},
+			Body: &ses.Body{
+				Text: &ses.Content{Data: aws.String(body.String())},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// slackDescriptionMaxLen keeps article summaries short enough to stay
+	// scannable inside a section block.
+	slackDescriptionMaxLen = 140
+)
+
+// slackMessage is the top level Incoming Webhook payload built with Block Kit.
+// https://api.slack.com/block-kit
+type slackMessage struct {
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type      string          `json:"type"`
+	Text      *slackText      `json:"text,omitempty"`
+	Accessory *slackAccessory `json:"accessory,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackAccessory struct {
+	Type     string `json:"type"`
+	ImageURL string `json:"image_url"`
+	AltText  string `json:"alt_text"`
+}
+
+// buildSlackMessage turns a digest into a Block Kit message: a header block
+// describing the keyword/date range, then one section block per article
+// (title linked, source, published date, truncated description, thumbnail
+// accessory), separated by dividers.
+func buildSlackMessage(digest Digest) slackMessage {
+	loc := tokyoLocation()
+
+	msg := slackMessage{
+		Blocks: []slackBlock{
+			{
+				Type: "header",
+				Text: &slackText{
+					Type: "plain_text",
+					Text: fmt.Sprintf("<!channel> %s (%s ~ %s)", digest.Keyword, digest.From, digest.To),
+				},
+			},
+		},
+	}
+
+	for _, article := range digest.Articles {
+		msg.Blocks = append(msg.Blocks, slackBlock{Type: "divider"})
+
+		published := article.PublishedAt.In(loc).Format("2006-01-02 15:04")
+		text := fmt.Sprintf("*<%s|%s>*\n%s ・ %s\n%s",
+			article.URL,
+			article.Title,
+			article.Source,
+			published,
+			truncate(article.Description, slackDescriptionMaxLen),
+		)
+		if footer := article.relatedFooter(); footer != "" {
+			text += "\n" + footer
+		}
+
+		block := slackBlock{
+			Type: "section",
+			Text: &slackText{
+				Type: "mrkdwn",
+				Text: text,
+			},
+		}
+		if article.URLToImage != "" {
+			block.Accessory = &slackAccessory{
+				Type:     "image",
+				ImageURL: article.URLToImage,
+				AltText:  article.Title,
+			}
+		}
+		msg.Blocks = append(msg.Blocks, block)
+	}
+
+	return msg
+}
+
+// truncate shortens s to at most max runes, appending an ellipsis if it had
+// to cut anything off.
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max]) + "..."
+}
+
+// slackNotifier posts a Block Kit digest to a Slack Incoming Webhook.
+type slackNotifier struct {
+	webhookURL string
+}
+
+func newSlackNotifier(webhookURL string) *slackNotifier {
+	return &slackNotifier{webhookURL: webhookURL}
+}
+
+func (n *slackNotifier) Name() string { return "slack" }
+
+func (n *slackNotifier) Send(ctx context.Context, digest Digest) error {
+	return postJSON(ctx, n.webhookURL, buildSlackMessage(digest))
+}
+
+func tokyoLocation() *time.Location {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		loc = time.FixedZone("Asia/Tokyo", 9*60*60)
+	}
+	return loc
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Source fetches articles matching query published between from and to
+// (both "2006-01-02", inclusive).
+type Source interface {
+	Name() string
+	Fetch(ctx context.Context, query, from, to string) ([]Article, error)
+}
+
+// buildSources returns one Source per name in key.Sources (case
+// insensitive). A PickupKey with no Sources configured falls back to
+// NewsAPI alone, matching the original single-source behaviour.
+func buildSources(env Env, key PickupKey) ([]Source, error) {
+	if len(key.Sources) == 0 {
+		return []Source{newNewsAPISource(env.Apikey)}, nil
+	}
+
+	var sources []Source
+	for _, name := range key.Sources {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "newsapi":
+			sources = append(sources, newNewsAPISource(env.Apikey))
+		case "gnews":
+			sources = append(sources, newGNewsSource(env.GNewsAPIKey))
+		case "bing":
+			sources = append(sources, newBingNewsSource(env.BingAPIKey))
+		case "rss":
+			sources = append(sources, newRSSSource(env.RSSFeedURLs))
+		case "hackernews":
+			sources = append(sources, newHackerNewsSource())
+		default:
+			return nil, fmt.Errorf("unknown article source %q", name)
+		}
+	}
+	return sources, nil
+}
+
+// fetchAllSources fetches query from every source concurrently and merges
+// the results, deduplicating by normalized URL. A source that fails doesn't
+// fail the whole fetch unless every source failed.
+func fetchAllSources(ctx context.Context, sources []Source, query, from, to string) ([]Article, error) {
+	type sourceResult struct {
+		articles []Article
+		err      error
+	}
+
+	results := make([]sourceResult, len(sources))
+	var wg sync.WaitGroup
+	for i, src := range sources {
+		i, src := i, src
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			articles, err := src.Fetch(ctx, query, from, to)
+			results[i] = sourceResult{articles: articles, err: err}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	var merged []Article
+	var errs []error
+	for i, res := range results {
+		if res.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sources[i].Name(), res.err))
+			continue
+		}
+		for _, article := range res.articles {
+			hash := articleHash(article.URL)
+			if seen[hash] {
+				continue
+			}
+			seen[hash] = true
+			merged = append(merged, article)
+		}
+	}
+
+	if len(errs) > 0 && len(merged) == 0 {
+		return nil, multiError(errs)
+	}
+	return merged, nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// teamsNotifier posts a digest to a Microsoft Teams Incoming Webhook using
+// the legacy MessageCard format (Teams connector cards).
+type teamsNotifier struct {
+	webhookURL string
+}
+
+func newTeamsNotifier(webhookURL string) *teamsNotifier {
+	return &teamsNotifier{webhookURL: webhookURL}
+}
+
+func (n *teamsNotifier) Name() string { return "teams" }
+
+type teamsMessageCard struct {
+	Type       string         `json:"@type"`
+	Context    string         `json:"@context"`
+	Summary    string         `json:"summary"`
+	ThemeColor string         `json:"themeColor,omitempty"`
+	Title      string         `json:"title"`
+	Sections   []teamsSection `json:"sections,omitempty"`
+}
+
+type teamsSection struct {
+	ActivityTitle    string               `json:"activityTitle,omitempty"`
+	ActivitySubtitle string               `json:"activitySubtitle,omitempty"`
+	ActivityImage    string               `json:"activityImage,omitempty"`
+	Text             string               `json:"text,omitempty"`
+	PotentialAction  []teamsOpenURIAction `json:"potentialAction,omitempty"`
+}
+
+type teamsOpenURIAction struct {
+	Type    string              `json:"@type"`
+	Name    string              `json:"name"`
+	Targets []teamsActionTarget `json:"targets"`
+}
+
+type teamsActionTarget struct {
+	OS  string `json:"os"`
+	URI string `json:"uri"`
+}
+
+func (n *teamsNotifier) Send(ctx context.Context, digest Digest) error {
+	title := fmt.Sprintf("%s (%s ~ %s)", digest.Keyword, digest.From, digest.To)
+	card := teamsMessageCard{
+		Type:       "MessageCard",
+		Context:    "http://schema.org/extensions",
+		Summary:    title,
+		ThemeColor: "0076D7",
+		Title:      title,
+	}
+
+	loc := tokyoLocation()
+	for _, article := range digest.Articles {
+		text := truncate(article.Description, slackDescriptionMaxLen)
+		if footer := article.relatedFooter(); footer != "" {
+			text += "\n" + footer
+		}
+
+		card.Sections = append(card.Sections, teamsSection{
+			ActivityTitle:    article.Title,
+			ActivitySubtitle: fmt.Sprintf("%s ・ %s", article.Source, article.PublishedAt.In(loc).Format("2006-01-02 15:04")),
+			ActivityImage:    article.URLToImage,
+			Text:             text,
+			PotentialAction: []teamsOpenURIAction{{
+				Type: "OpenUri",
+				Name: "Read article",
+				Targets: []teamsActionTarget{
+					{OS: "default", URI: article.URL},
+				},
+			}},
+		})
+	}
+
+	return postJSON(ctx, n.webhookURL, card)
+}
@@ -0,0 +1,19 @@
+package main
+
+import "context"
+
+// webhookNotifier posts the raw Digest as JSON to an arbitrary URL, for
+// destinations that don't speak a chat-specific payload format.
+type webhookNotifier struct {
+	url string
+}
+
+func newWebhookNotifier(url string) *webhookNotifier {
+	return &webhookNotifier{url: url}
+}
+
+func (n *webhookNotifier) Name() string { return "webhook" }
+
+func (n *webhookNotifier) Send(ctx context.Context, digest Digest) error {
+	return postJSON(ctx, n.url, digest)
+}